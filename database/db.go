@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Driver database driver name
+type Driver string
+
+const (
+	DriverSQLite   Driver = "sqlite"
+	DriverMySQL    Driver = "mysql"
+	DriverPostgres Driver = "postgres"
+)
+
+// DB unified database access interface, implemented by SQLiteDB, MySqlDB and PostgresDB
+type DB interface {
+	// Exec perform non return SQL execute
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	// ExecContext perform SQL execute with context
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	// Query perform query SQL
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	// QueryContext perform query SQL with context
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	// QueryRow perform query row SQL
+	QueryRow(query string, args ...interface{}) *sql.Row
+	// QueryRowContext perform query row SQL with context
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	// BeginTx begin transaction with context
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	// Ping database connection
+	Ping(ctx context.Context) error
+	// Close database connect
+	Close() error
+	// Prepare processing
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+var (
+	_ DB = (*SQLiteDB)(nil)
+	_ DB = (*MySqlDB)(nil)
+	_ DB = (*PostgresDB)(nil)
+)
+
+// OpenConfig configure used by Open to dispatch on Driver
+type OpenConfig struct {
+	Driver   Driver
+	SQLite   SQLiteConfig
+	MySQL    Config
+	Postgres PostgresConfig
+}
+
+// SQLiteConfig SQLite configure
+type SQLiteConfig struct {
+	Path string
+}
+
+// Open create database by configure, dispatching on cfg.Driver
+func Open(ctx context.Context, cfg OpenConfig) (DB, error) {
+	switch cfg.Driver {
+	case DriverSQLite:
+		return NewSQLiteDB(cfg.SQLite.Path)
+	case DriverMySQL:
+		return NewMySqlDB(ctx, cfg.MySQL)
+	case DriverPostgres:
+		return NewPostgresDB(ctx, cfg.Postgres)
+	default:
+		return nil, fmt.Errorf("unsupported driver: %s", cfg.Driver)
+	}
+}
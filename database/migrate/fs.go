@@ -0,0 +1,83 @@
+package migrate
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+)
+
+// migrationFileName matches NNNN_name.(up|down).sql
+var migrationFileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// RegisterFS register every NNNN_name.up.sql / NNNN_name.down.sql pair found under dir in fsys
+func (m *Migrator) RegisterFS(fsys embed.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("error read migrations dir: %w", err)
+	}
+	type pair struct {
+		name             string
+		upSQL, downSQL   string
+		haveUp, haveDown bool
+	}
+	pairs := make(map[int]*pair)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return fmt.Errorf("error parse migration version in %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, dir+"/"+entry.Name())
+		if err != nil {
+			return fmt.Errorf("error read migration file %s: %w", entry.Name(), err)
+		}
+		p, ok := pairs[version]
+		if !ok {
+			p = &pair{name: match[2]}
+			pairs[version] = p
+		}
+		switch match[3] {
+		case "up":
+			p.upSQL = string(content)
+			p.haveUp = true
+		case "down":
+			p.downSQL = string(content)
+			p.haveDown = true
+		}
+	}
+	for version, p := range pairs {
+		if !p.haveUp {
+			return fmt.Errorf("migration %d (%s) missing .up.sql file", version, p.name)
+		}
+		if !p.haveDown {
+			return fmt.Errorf("migration %d (%s) missing .down.sql file", version, p.name)
+		}
+		upSQL, downSQL := p.upSQL, p.downSQL
+		if _, exists := m.migrations[version]; exists {
+			return fmt.Errorf("migration %d already registered", version)
+		}
+		m.migrations[version] = &Migration{
+			Version: version,
+			Name:    p.name,
+			Up: func(tx *sql.Tx) error {
+				_, err := tx.Exec(upSQL)
+				return err
+			},
+			Down: func(tx *sql.Tx) error {
+				_, err := tx.Exec(downSQL)
+				return err
+			},
+			checksum: checksum(upSQL, downSQL),
+		}
+	}
+	return nil
+}
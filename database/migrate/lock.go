@@ -0,0 +1,133 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/gorgeousbubble/ePDG/database"
+)
+
+// advisoryLockKey arbitrary key shared by all ePDG schema migrators
+const advisoryLockKey = 834512
+
+// sqliteLock in-process mutex serializing the read-then-decide window of Up/Down/Goto within
+// this process. Cross-process exclusion for SQLite comes from the writer DSN's _txlock=immediate
+// (see NewSQLiteDB/NewSQLiteDBWithOptions), which makes every per-migration BeginTx take a real
+// BEGIN IMMEDIATE — SQLite's RESERVED file lock — for the lifetime of that migration's transaction.
+var sqliteLock sync.Mutex
+
+// conner is implemented by backends (MySqlDB, PostgresDB) whose session-scoped advisory
+// locks must be acquired and released on the exact same underlying connection
+type conner interface {
+	Conn(ctx context.Context) (*sql.Conn, error)
+}
+
+// schemaMigrationsDDL return the CREATE TABLE statement for schema_migrations on driver
+func schemaMigrationsDDL(driver database.Driver) string {
+	switch driver {
+	case database.DriverPostgres:
+		return `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT now(),
+			checksum TEXT NOT NULL
+		)`
+	case database.DriverMySQL:
+		return `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			checksum TEXT NOT NULL
+		)`
+	default: // database.DriverSQLite
+		return `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			checksum TEXT NOT NULL
+		)`
+	}
+}
+
+// insertMigrationSQL return the parameterized INSERT statement for recording an applied migration
+func insertMigrationSQL(driver database.Driver) string {
+	return fmt.Sprintf(
+		"INSERT INTO schema_migrations (version, checksum) VALUES (%s, %s)",
+		placeholder(driver, 1),
+		placeholder(driver, 2),
+	)
+}
+
+// placeholder return the driver-appropriate bind parameter for position n (1-indexed)
+func placeholder(driver database.Driver, n int) string {
+	if driver == database.DriverPostgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// acquireLock take the per-backend advisory lock, returning a release func to call when done.
+// For Postgres and MySQL the lock is session-scoped, so it must be acquired and released on
+// the same pinned *sql.Conn — routing acquire/release through independent pool-checkout Exec
+// calls can silently land on different connections and leak the lock forever.
+func acquireLock(ctx context.Context, db database.DB, driver database.Driver) (func(ctx context.Context) error, error) {
+	switch driver {
+	case database.DriverPostgres:
+		conn, err := reserveConn(ctx, db, driver)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		return func(ctx context.Context) error {
+			defer conn.Close()
+			_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+			return err
+		}, nil
+	case database.DriverMySQL:
+		conn, err := reserveConn(ctx, db, driver)
+		if err != nil {
+			return nil, err
+		}
+		lockName := fmt.Sprintf("epdg_migrate_%d", advisoryLockKey)
+		var result sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 30)", lockName).Scan(&result); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("error acquire GET_LOCK: %w", err)
+		}
+		if !result.Valid || result.Int64 != 1 {
+			_ = conn.Close()
+			return nil, fmt.Errorf("error acquire GET_LOCK %q: timed out or errored (result=%v)", lockName, result)
+		}
+		return func(ctx context.Context) error {
+			defer conn.Close()
+			var result sql.NullInt64
+			if err := conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", lockName).Scan(&result); err != nil {
+				return fmt.Errorf("error release RELEASE_LOCK: %w", err)
+			}
+			if !result.Valid || result.Int64 != 1 {
+				return fmt.Errorf("error release RELEASE_LOCK %q: not held by this session (result=%v)", lockName, result)
+			}
+			return nil
+		}, nil
+	default: // database.DriverSQLite
+		// in-process-only; each migration's own transaction additionally takes a real
+		// cross-process BEGIN IMMEDIATE lock via the writer DSN's _txlock=immediate
+		sqliteLock.Lock()
+		return func(ctx context.Context) error {
+			sqliteLock.Unlock()
+			return nil
+		}, nil
+	}
+}
+
+// reserveConn check out a single connection from db's pool, pinned for the lifetime of the
+// advisory lock held on it
+func reserveConn(ctx context.Context, db database.DB, driver database.Driver) (*sql.Conn, error) {
+	c, ok := db.(conner)
+	if !ok {
+		return nil, fmt.Errorf("%s backend does not support Conn(), cannot pin an advisory lock to a single connection", driver)
+	}
+	return c.Conn(ctx)
+}
@@ -0,0 +1,288 @@
+// Package migrate provides versioned schema migrations for database.DB backends.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gorgeousbubble/ePDG/database"
+	"github.com/gorgeousbubble/ePDG/logger"
+)
+
+// Migration single versioned schema change
+type Migration struct {
+	Version  int
+	Name     string
+	Up       func(tx *sql.Tx) error
+	Down     func(tx *sql.Tx) error
+	checksum string
+}
+
+// Status describes the applied/pending state of a single migration
+type Status struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+// Migrator runs registered migrations against a database.DB
+type Migrator struct {
+	db         database.DB
+	driver     database.Driver
+	migrations map[int]*Migration
+}
+
+// New create a Migrator bound to db, dispatching locking/DDL by driver
+func New(db database.DB, driver database.Driver) *Migrator {
+	return &Migrator{
+		db:         db,
+		driver:     driver,
+		migrations: make(map[int]*Migration),
+	}
+}
+
+// Register add a migration programmatically
+func (m *Migrator) Register(version int, up, down func(tx *sql.Tx) error) error {
+	if _, exists := m.migrations[version]; exists {
+		return fmt.Errorf("migration %d already registered", version)
+	}
+	m.migrations[version] = &Migration{
+		Version: version,
+		Up:      up,
+		Down:    down,
+	}
+	return nil
+}
+
+// ensureSchemaTable create the schema_migrations bookkeeping table if absent
+func (m *Migrator) ensureSchemaTable(ctx context.Context) error {
+	ddl := schemaMigrationsDDL(m.driver)
+	if _, err := m.db.ExecContext(ctx, ddl); err != nil {
+		return fmt.Errorf("error create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// sortedVersions return registered migration versions in ascending order
+func (m *Migrator) sortedVersions() []int {
+	versions := make([]int, 0, len(m.migrations))
+	for v := range m.migrations {
+		versions = append(versions, v)
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+// appliedVersions read the set of already-applied versions with their checksums
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]string, error) {
+	rows, err := m.db.QueryContext(ctx, "SELECT version, checksum FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("error query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+	applied := make(map[int]string)
+	for rows.Next() {
+		var version int
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, fmt.Errorf("error scan schema_migrations row: %w", err)
+		}
+		applied[version] = checksum
+	}
+	return applied, rows.Err()
+}
+
+// verifyChecksum ensures an already-applied migration's source has not drifted
+func (m *Migrator) verifyChecksum(version int, migration *Migration, recorded string) error {
+	if migration.checksum == "" {
+		return nil
+	}
+	if recorded != migration.checksum {
+		return fmt.Errorf("migration %d checksum mismatch: recorded %s, expected %s", version, recorded, migration.checksum)
+	}
+	return nil
+}
+
+// Up apply all pending migrations in ascending order
+func (m *Migrator) Up(ctx context.Context) error {
+	release, err := acquireLock(ctx, m.db, m.driver)
+	if err != nil {
+		return fmt.Errorf("error acquire migration lock: %w", err)
+	}
+	defer release(ctx)
+	if err := m.ensureSchemaTable(ctx); err != nil {
+		return err
+	}
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, version := range m.sortedVersions() {
+		migration := m.migrations[version]
+		if checksum, ok := applied[version]; ok {
+			if err := m.verifyChecksum(version, migration, checksum); err != nil {
+				return err
+			}
+			continue
+		}
+		logger.Infof("migrate: applying version %d (%s)", version, migration.Name)
+		if err := m.applyUp(ctx, migration); err != nil {
+			return fmt.Errorf("error apply migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// applyUp run a single migration's Up function inside its own transaction
+func (m *Migrator) applyUp(ctx context.Context, migration *Migration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error begin transaction: %w", err)
+	}
+	if err := migration.Up(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(insertMigrationSQL(m.driver), migration.Version, migration.checksum); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("error record migration: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Down roll back the given number of applied migrations, most recent first
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return errors.New("steps must be positive")
+	}
+	release, err := acquireLock(ctx, m.db, m.driver)
+	if err != nil {
+		return fmt.Errorf("error acquire migration lock: %w", err)
+	}
+	defer release(ctx)
+	if err := m.ensureSchemaTable(ctx); err != nil {
+		return err
+	}
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+	versions := m.sortedVersions()
+	for i := len(versions) - 1; i >= 0 && steps > 0; i-- {
+		version := versions[i]
+		if _, ok := applied[version]; !ok {
+			continue
+		}
+		migration := m.migrations[version]
+		logger.Infof("migrate: reverting version %d (%s)", version, migration.Name)
+		if err := m.applyDown(ctx, migration); err != nil {
+			return fmt.Errorf("error revert migration %d: %w", version, err)
+		}
+		steps--
+	}
+	return nil
+}
+
+// applyDown run a single migration's Down function inside its own transaction
+func (m *Migrator) applyDown(ctx context.Context, migration *Migration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error begin transaction: %w", err)
+	}
+	if err := migration.Down(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM schema_migrations WHERE version = "+placeholder(m.driver, 1), migration.Version); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("error remove migration record: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Goto migrate up or down to land exactly on target version, applying only migrations
+// with version <= target and reverting any applied migration with version > target
+func (m *Migrator) Goto(ctx context.Context, target int) error {
+	release, err := acquireLock(ctx, m.db, m.driver)
+	if err != nil {
+		return fmt.Errorf("error acquire migration lock: %w", err)
+	}
+	defer release(ctx)
+	if err := m.ensureSchemaTable(ctx); err != nil {
+		return err
+	}
+
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, version := range m.sortedVersions() {
+		if version > target {
+			continue
+		}
+		if _, ok := applied[version]; ok {
+			continue
+		}
+		migration := m.migrations[version]
+		logger.Infof("migrate: applying version %d (%s)", version, migration.Name)
+		if err := m.applyUp(ctx, migration); err != nil {
+			return fmt.Errorf("error apply migration %d: %w", version, err)
+		}
+	}
+
+	// re-read applied versions: the loop above may have changed them, and Down must only
+	// touch what's actually applied above target, not a snapshot taken before applying up
+	applied, err = m.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+	versions := m.sortedVersions()
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+		if version <= target {
+			continue
+		}
+		if _, ok := applied[version]; !ok {
+			continue
+		}
+		migration := m.migrations[version]
+		logger.Infof("migrate: reverting version %d (%s)", version, migration.Name)
+		if err := m.applyDown(ctx, migration); err != nil {
+			return fmt.Errorf("error revert migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// Status report the applied/pending state of every registered migration
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.ensureSchemaTable(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, version := range m.sortedVersions() {
+		migration := m.migrations[version]
+		_, ok := applied[version]
+		statuses = append(statuses, Status{
+			Version: version,
+			Name:    migration.Name,
+			Applied: ok,
+		})
+	}
+	return statuses, nil
+}
+
+// checksum compute the sha256 checksum of migration source text
+func checksum(upSQL, downSQL string) string {
+	sum := sha256.Sum256([]byte(upSQL + "\x00" + downSQL))
+	return hex.EncodeToString(sum[:])
+}
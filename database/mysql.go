@@ -21,10 +21,11 @@ type Config struct {
 	Port     int
 	Database string
 	Charset  string
+	Retry    *RetryPolicy
 }
 
 // NewMySqlDB create MySQL database
-func NewMySqlDB(cfg Config) (*MySqlDB, error) {
+func NewMySqlDB(ctx context.Context, cfg Config) (*MySqlDB, error) {
 	// default charset
 	charset := cfg.Charset
 	if charset == "" {
@@ -48,8 +49,8 @@ func NewMySqlDB(cfg Config) (*MySqlDB, error) {
 	db.SetMaxOpenConns(25)
 	db.SetMaxIdleConns(10)
 	db.SetConnMaxLifetime(time.Hour)
-	// verify connect
-	if err = db.Ping(); err != nil {
+	// verify connect, retrying with backoff if a RetryPolicy is configured
+	if err = connectWithRetry(ctx, cfg.Retry, func() error { return db.PingContext(ctx) }); err != nil {
 		return nil, fmt.Errorf("error verify connect: %w", err)
 	}
 	return &MySqlDB{db: db}, nil
@@ -69,25 +70,41 @@ func (m *MySqlDB) Ping(ctx context.Context) error {
 	return m.db.PingContext(ctx)
 }
 
-// Exec perform execute(insert/query/delete)
-func (m *MySqlDB) Exec(query string, args ...interface{}) (int64, error) {
+// Stats report connection pool statistics
+func (m *MySqlDB) Stats() sql.DBStats {
+	return m.db.Stats()
+}
+
+// Conn reserve a single connection from the pool, for callers that need session-scoped
+// state (e.g. GET_LOCK/RELEASE_LOCK) to land on the same underlying connection
+func (m *MySqlDB) Conn(ctx context.Context) (*sql.Conn, error) {
+	return m.db.Conn(ctx)
+}
+
+// Exec perform non return SQL execute
+func (m *MySqlDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return m.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext perform SQL execute with context
+func (m *MySqlDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	// perform execute
-	result, err := m.db.Exec(query, args...)
+	result, err := m.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return 0, fmt.Errorf("error execute: %w", err)
+		return nil, fmt.Errorf("error execute: %w", err)
 	}
-	// fetch rows affected
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return 0, fmt.Errorf("error fetch rows affected: %w", err)
-	}
-	return rowsAffected, nil
+	return result, nil
 }
 
 // Query query multiple rows
 func (m *MySqlDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return m.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext query multiple rows with context
+func (m *MySqlDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	// perform query multiple rows
-	rows, err := m.db.Query(query, args...)
+	rows, err := m.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error query: %w", err)
 	}
@@ -96,14 +113,19 @@ func (m *MySqlDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
 
 // QueryRow query single row
 func (m *MySqlDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return m.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext query single row with context
+func (m *MySqlDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	// perform query single row
-	return m.db.QueryRow(query, args...)
+	return m.db.QueryRowContext(ctx, query, args...)
 }
 
-// BeginTransaction begin transaction
-func (m *MySqlDB) BeginTransaction() (*sql.Tx, error) {
+// BeginTx begin transaction with context
+func (m *MySqlDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
 	// perform begin transaction
-	tx, err := m.db.Begin()
+	tx, err := m.db.BeginTx(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error begin transaction: %w", err)
 	}
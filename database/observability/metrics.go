@@ -0,0 +1,70 @@
+package observability
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// queryDuration histogram of query latency by operation and table
+var queryDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "db_query_duration_seconds",
+		Help: "Duration of database queries in seconds, by operation and table.",
+	},
+	[]string{"operation", "table"},
+)
+
+// poolOpen, poolIdle, poolInUse gauges of sql.DB connection pool state, by pool name
+var (
+	poolOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{Name: "db_pool_open_connections", Help: "Number of established connections."},
+		[]string{"pool"},
+	)
+	poolIdle = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{Name: "db_pool_idle_connections", Help: "Number of idle connections."},
+		[]string{"pool"},
+	)
+	poolInUse = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{Name: "db_pool_in_use_connections", Help: "Number of connections currently in use."},
+		[]string{"pool"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(queryDuration, poolOpen, poolIdle, poolInUse)
+}
+
+// statser is implemented by database.DB backends that expose connection pool stats
+type statser interface {
+	Stats() sql.DBStats
+}
+
+// collectPoolStats starts a ticker that scrapes db.Stats() into the pool gauges for name,
+// until ctx is done. If db does not implement statser, collectPoolStats is a no-op.
+func collectPoolStats(ctx context.Context, name string, db interface{}, interval time.Duration) {
+	s, ok := db.(statser)
+	if !ok {
+		return
+	}
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				stats := s.Stats()
+				poolOpen.WithLabelValues(name).Set(float64(stats.OpenConnections))
+				poolIdle.WithLabelValues(name).Set(float64(stats.Idle))
+				poolInUse.WithLabelValues(name).Set(float64(stats.InUse))
+			}
+		}
+	}()
+}
@@ -0,0 +1,51 @@
+package observability
+
+import (
+	"strings"
+)
+
+// parseStatement extract the first keyword and the table name from a SQL statement
+// without inspecting bound parameter values, for use as low-cardinality metric labels.
+func parseStatement(query string) (operation, table string) {
+	fields := strings.Fields(strings.TrimSpace(query))
+	if len(fields) == 0 {
+		return "unknown", "unknown"
+	}
+	operation = strings.ToUpper(fields[0])
+	switch operation {
+	case "SELECT":
+		table = tableAfter(fields, "FROM")
+	case "INSERT":
+		table = tableAfter(fields, "INTO")
+	case "UPDATE":
+		if len(fields) > 1 {
+			table = stripQuoting(fields[1])
+		}
+	case "DELETE":
+		table = tableAfter(fields, "FROM")
+	default:
+		table = "unknown"
+	}
+	if table == "" {
+		table = "unknown"
+	}
+	return operation, table
+}
+
+// tableAfter return the identifier immediately following the first case-insensitive
+// occurrence of keyword in fields
+func tableAfter(fields []string, keyword string) string {
+	for i, field := range fields {
+		if strings.EqualFold(field, keyword) && i+1 < len(fields) {
+			return stripQuoting(fields[i+1])
+		}
+	}
+	return ""
+}
+
+// stripQuoting remove surrounding quotes/backticks and a trailing comma or parenthesis
+func stripQuoting(identifier string) string {
+	identifier = strings.TrimRight(identifier, ",();")
+	identifier = strings.Trim(identifier, "`\"'[]")
+	return identifier
+}
@@ -0,0 +1,154 @@
+// Package observability wraps a database.DB with OpenTelemetry tracing and
+// Prometheus metrics, uniformly across the SQLite, MySQL and Postgres backends.
+package observability
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gorgeousbubble/ePDG/database"
+)
+
+// Options configure Wrap
+type Options struct {
+	// System is recorded as the db.system span attribute, e.g. "sqlite", "mysql", "postgres"
+	System string
+	// PoolName labels the db_pool_* gauges, so multiple wrapped DBs stay distinguishable
+	PoolName string
+	// TracerName is passed to otel.Tracer, defaults to "database" when empty
+	TracerName string
+	// PoolScrapeInterval controls how often sql.DB.Stats() is scraped, defaults to 15s
+	PoolScrapeInterval time.Duration
+	// StatsContext governs the lifetime of the background pool-stats ticker, defaults to context.Background()
+	StatsContext context.Context
+}
+
+// wrappedDB instruments a database.DB with tracing and metrics
+type wrappedDB struct {
+	db        database.DB
+	tracer    trace.Tracer
+	system    string
+	stopStats context.CancelFunc
+}
+
+var _ database.DB = (*wrappedDB)(nil)
+
+// Wrap return a database.DB that records OTel spans and Prometheus metrics around db.
+// The pool-stats scraper it starts is stopped by Close, so callers don't need to manage
+// StatsContext's lifetime themselves to avoid leaking the scraper goroutine.
+func Wrap(db database.DB, opts Options) database.DB {
+	tracerName := opts.TracerName
+	if tracerName == "" {
+		tracerName = "database"
+	}
+	parent := opts.StatsContext
+	if parent == nil {
+		parent = context.Background()
+	}
+	statsCtx, stopStats := context.WithCancel(parent)
+	collectPoolStats(statsCtx, opts.PoolName, db, opts.PoolScrapeInterval)
+	return &wrappedDB{
+		db:        db,
+		tracer:    otel.Tracer(tracerName),
+		system:    opts.System,
+		stopStats: stopStats,
+	}
+}
+
+// begin start a span for query and the metrics timer associated with it
+func (w *wrappedDB) begin(ctx context.Context, spanName, query string) (context.Context, trace.Span, string, string, time.Time) {
+	operation, table := parseStatement(query)
+	ctx, span := w.tracer.Start(ctx, spanName, trace.WithAttributes(
+		attribute.String("db.system", w.system),
+		attribute.String("db.statement", query),
+	))
+	return ctx, span, operation, table, time.Now()
+}
+
+// finish record the query duration metric and close out the span
+func (w *wrappedDB) finish(span trace.Span, operation, table string, start time.Time, rowsAffected int64, err error) {
+	queryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+	if rowsAffected >= 0 {
+		span.SetAttributes(attribute.Int64("db.rows_affected", rowsAffected))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Exec perform non return SQL execute
+func (w *wrappedDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return w.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext perform SQL execute with context
+func (w *wrappedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span, operation, table, start := w.begin(ctx, "db.exec", query)
+	result, err := w.db.ExecContext(ctx, query, args...)
+	rowsAffected := int64(-1)
+	if err == nil {
+		if n, rErr := result.RowsAffected(); rErr == nil {
+			rowsAffected = n
+		}
+	}
+	w.finish(span, operation, table, start, rowsAffected, err)
+	return result, err
+}
+
+// Query perform query SQL
+func (w *wrappedDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return w.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext perform query SQL with context
+func (w *wrappedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span, operation, table, start := w.begin(ctx, "db.query", query)
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	w.finish(span, operation, table, start, -1, err)
+	return rows, err
+}
+
+// QueryRow perform query row SQL
+func (w *wrappedDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return w.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext perform query row SQL with context
+func (w *wrappedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span, operation, table, start := w.begin(ctx, "db.query_row", query)
+	row := w.db.QueryRowContext(ctx, query, args...)
+	w.finish(span, operation, table, start, -1, nil)
+	return row
+}
+
+// BeginTx begin transaction with context
+func (w *wrappedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	ctx, span, _, _, start := w.begin(ctx, "db.begin_tx", "BEGIN")
+	tx, err := w.db.BeginTx(ctx, opts)
+	w.finish(span, "BEGIN", "", start, -1, err)
+	return tx, err
+}
+
+// Ping database connection
+func (w *wrappedDB) Ping(ctx context.Context) error {
+	return w.db.Ping(ctx)
+}
+
+// Close database connect, stopping the background pool-stats scraper
+func (w *wrappedDB) Close() error {
+	w.stopStats()
+	return w.db.Close()
+}
+
+// Prepare processing
+func (w *wrappedDB) Prepare(query string) (*sql.Stmt, error) {
+	return w.db.Prepare(query)
+}
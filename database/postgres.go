@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/lib/pq"
+	"time"
+)
+
+// PostgresDB structure
+type PostgresDB struct {
+	db *sql.DB
+}
+
+// PostgresConfig Postgres configure
+type PostgresConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Database string
+	SSLMode  string
+	Retry    *RetryPolicy
+}
+
+// NewPostgresDB create Postgres database
+func NewPostgresDB(ctx context.Context, cfg PostgresConfig) (*PostgresDB, error) {
+	// default sslmode
+	sslMode := cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	// DSN configure
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.Database,
+		sslMode,
+	)
+	// open Postgres database
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error open database: %w", err)
+	}
+	// set connect pool parameters
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(time.Hour)
+	// verify connect, retrying with backoff if a RetryPolicy is configured
+	if err = connectWithRetry(ctx, cfg.Retry, func() error { return db.PingContext(ctx) }); err != nil {
+		return nil, fmt.Errorf("error verify connect: %w", err)
+	}
+	return &PostgresDB{db: db}, nil
+}
+
+// Close database connect
+func (p *PostgresDB) Close() error {
+	if p.db != nil {
+		return p.db.Close()
+	}
+	return nil
+}
+
+// Ping database connection
+func (p *PostgresDB) Ping(ctx context.Context) error {
+	// ping context
+	return p.db.PingContext(ctx)
+}
+
+// Stats report connection pool statistics
+func (p *PostgresDB) Stats() sql.DBStats {
+	return p.db.Stats()
+}
+
+// Conn reserve a single connection from the pool, for callers that need session-scoped
+// state (e.g. pg_advisory_lock/pg_advisory_unlock) to land on the same underlying connection
+func (p *PostgresDB) Conn(ctx context.Context) (*sql.Conn, error) {
+	return p.db.Conn(ctx)
+}
+
+// Exec perform non return SQL execute
+func (p *PostgresDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return p.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext perform SQL execute with context
+func (p *PostgresDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	// perform execute
+	result, err := p.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error execute: %w", err)
+	}
+	return result, nil
+}
+
+// Query query multiple rows
+func (p *PostgresDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return p.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext query multiple rows with context
+func (p *PostgresDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	// perform query multiple rows
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error query: %w", err)
+	}
+	return rows, nil
+}
+
+// QueryRow query single row
+func (p *PostgresDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return p.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext query single row with context
+func (p *PostgresDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	// perform query single row
+	return p.db.QueryRowContext(ctx, query, args...)
+}
+
+// BeginTx begin transaction with context
+func (p *PostgresDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	// perform begin transaction
+	tx, err := p.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// Prepare processing
+func (p *PostgresDB) Prepare(query string) (*sql.Stmt, error) {
+	stmt, err := p.db.Prepare(query)
+	if err != nil {
+		return nil, fmt.Errorf("error prepare: %w", err)
+	}
+	return stmt, nil
+}
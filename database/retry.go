@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gorgeousbubble/ePDG/logger"
+)
+
+// RetryPolicy controls connection bootstrap retries with exponential backoff
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Jitter         bool
+}
+
+// connectWithRetry call ping repeatedly until it succeeds, policy is exhausted, or ctx is cancelled
+func connectWithRetry(ctx context.Context, policy *RetryPolicy, ping func() error) error {
+	if policy == nil {
+		return ping()
+	}
+	if policy.MaxAttempts < 1 {
+		return fmt.Errorf("invalid retry policy: MaxAttempts must be >= 1, got %d", policy.MaxAttempts)
+	}
+	backoff := policy.InitialBackoff
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if err = ping(); err == nil {
+			return nil
+		}
+		logger.Warnf("database: connect attempt %d/%d failed: %v", attempt, policy.MaxAttempts, err)
+		if attempt == policy.MaxAttempts {
+			break
+		}
+		wait := backoff
+		if policy.Jitter {
+			wait = time.Duration(rand.Int63n(int64(backoff) + 1))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+	return fmt.Errorf("error connect after %d attempts: %w", policy.MaxAttempts, err)
+}
@@ -6,21 +6,33 @@ import (
 	"errors"
 	"fmt"
 	_ "modernc.org/sqlite"
+	"strings"
 	"sync"
 	"time"
 )
 
 // SQLiteDB SQLite3 database
 type SQLiteDB struct {
-	db   *sql.DB
-	path string
-	mu   sync.Mutex
+	db     *sql.DB // writer connection, guarded by mu
+	readDB *sql.DB // optional pool of read-only connections, set under WAL mode; nil otherwise
+	path   string
+	mu     sync.Mutex
+}
+
+// appendDSNParam add a query parameter to a SQLite DSN, whether or not it already has one
+func appendDSNParam(dsn, key, value string) string {
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + key + "=" + value
 }
 
 // NewSQLiteDB create SQLite database
 func NewSQLiteDB(dbPath string) (*SQLiteDB, error) {
-	// create sqlite3 database
-	db, err := sql.Open("sqlite", dbPath)
+	// _txlock=immediate makes every BeginTx issue BEGIN IMMEDIATE, so each transaction takes
+	// SQLite's real, cross-process RESERVED file lock for its duration instead of a deferred one
+	db, err := sql.Open("sqlite", appendDSNParam(dbPath, "_txlock", "immediate"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -47,13 +59,21 @@ func (s *SQLiteDB) Close() error {
 	// sqlite3 database safe-lock
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	// close read-only pool, if any
+	var readErr error
+	if s.readDB != nil {
+		readErr = s.readDB.Close()
+		s.readDB = nil
+	}
 	// close database
 	if s.db != nil {
 		err := s.db.Close()
 		s.db = nil
-		return err
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+	return readErr
 }
 
 // Exec perform non return SQL execute
@@ -81,6 +101,10 @@ func (s *SQLiteDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
 
 // QueryContext perform query SQL with context
 func (s *SQLiteDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	// under WAL mode, reads go through the read-only pool and never wait on the writer mutex
+	if reader := s.readPool(); reader != nil {
+		return reader.QueryContext(ctx, query, args...)
+	}
 	// sqlite3 database safe-lock
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -92,6 +116,13 @@ func (s *SQLiteDB) QueryContext(ctx context.Context, query string, args ...inter
 	return s.db.QueryContext(ctx, query, args...)
 }
 
+// readPool return the read-only connection pool, if configured
+func (s *SQLiteDB) readPool() *sql.DB {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readDB
+}
+
 // QueryRow perform query row SQL
 func (s *SQLiteDB) QueryRow(query string, args ...interface{}) *sql.Row {
 	return s.QueryRowContext(context.Background(), query, args...)
@@ -99,6 +130,10 @@ func (s *SQLiteDB) QueryRow(query string, args ...interface{}) *sql.Row {
 
 // QueryRowContext perform query row SQL with context
 func (s *SQLiteDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	// under WAL mode, reads go through the read-only pool and never wait on the writer mutex
+	if reader := s.readPool(); reader != nil {
+		return reader.QueryRowContext(ctx, query, args...)
+	}
 	// sqlite3 database safe-lock
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -110,13 +145,8 @@ func (s *SQLiteDB) QueryRowContext(ctx context.Context, query string, args ...in
 	return s.db.QueryRowContext(ctx, query, args...)
 }
 
-// BeginTx begin transaction
-func (s *SQLiteDB) BeginTx() (*sql.Tx, error) {
-	return s.BeginTxContext(context.Background(), nil)
-}
-
-// BeginTxContext begin transaction with action
-func (s *SQLiteDB) BeginTxContext(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+// BeginTx begin transaction with context
+func (s *SQLiteDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
 	// sqlite3 database safe-lock
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -127,3 +157,43 @@ func (s *SQLiteDB) BeginTxContext(ctx context.Context, opts *sql.TxOptions) (*sq
 	// perform begin transaction
 	return s.db.BeginTx(ctx, opts)
 }
+
+// Ping database connection
+func (s *SQLiteDB) Ping(ctx context.Context) error {
+	// sqlite3 database safe-lock
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// check database connect
+	if s.db == nil {
+		return errors.New("database not connected")
+	}
+	// ping context
+	return s.db.PingContext(ctx)
+}
+
+// Stats report connection pool statistics
+func (s *SQLiteDB) Stats() sql.DBStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return sql.DBStats{}
+	}
+	return s.db.Stats()
+}
+
+// Prepare processing
+func (s *SQLiteDB) Prepare(query string) (*sql.Stmt, error) {
+	// sqlite3 database safe-lock
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// check database connect
+	if s.db == nil {
+		return nil, errors.New("database not connected")
+	}
+	// perform prepare
+	stmt, err := s.db.Prepare(query)
+	if err != nil {
+		return nil, fmt.Errorf("error prepare: %w", err)
+	}
+	return stmt, nil
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gorgeousbubble/ePDG/logger"
+)
+
+// SQLiteOptions tunes the PRAGMAs and connection pools used by NewSQLiteDBWithOptions
+type SQLiteOptions struct {
+	JournalMode   string // default "WAL"
+	Synchronous   string // default "NORMAL"
+	BusyTimeout   int    // milliseconds, default 5000
+	CacheSize     int    // pragma cache_size, default 0 (driver default)
+	ForeignKeys   *bool  // default true; pass a pointer to false to explicitly disable
+	MmapSize      int64  // bytes, default 0 (disabled)
+	MaxReadConns  int    // size of the read-only pool under WAL mode, default 4
+	MaxWriteConns int    // size of the writer pool, default 1
+}
+
+// withDefaults fill unset fields with the package defaults
+func (o SQLiteOptions) withDefaults() SQLiteOptions {
+	if o.JournalMode == "" {
+		o.JournalMode = "WAL"
+	}
+	if o.Synchronous == "" {
+		o.Synchronous = "NORMAL"
+	}
+	if o.BusyTimeout == 0 {
+		o.BusyTimeout = 5000
+	}
+	if o.MaxReadConns == 0 {
+		o.MaxReadConns = 4
+	}
+	if o.MaxWriteConns == 0 {
+		o.MaxWriteConns = 1
+	}
+	if o.ForeignKeys == nil {
+		enabled := true
+		o.ForeignKeys = &enabled
+	}
+	return o
+}
+
+// NewSQLiteDBWithOptions create a SQLite database with explicit journal/sync/pool tuning.
+// Under WAL mode, a single writer connection handles Exec/BeginTx while a separate pool of
+// read-only connections handles Query/QueryRow, so concurrent readers no longer block on
+// the write mutex.
+func NewSQLiteDBWithOptions(dbPath string, opts SQLiteOptions) (*SQLiteDB, error) {
+	opts = opts.withDefaults()
+
+	// _txlock=immediate makes every BeginTx issue BEGIN IMMEDIATE, so each transaction takes
+	// SQLite's real, cross-process RESERVED file lock for its duration instead of a deferred one
+	writeDB, err := sql.Open("sqlite", appendDSNParam(dbPath, "_txlock", "immediate"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	writeDB.SetMaxOpenConns(opts.MaxWriteConns)
+	writeDB.SetMaxIdleConns(opts.MaxWriteConns)
+	writeDB.SetConnMaxLifetime(time.Hour)
+	if err := writeDB.Ping(); err != nil {
+		return nil, fmt.Errorf("database ping failed: %w", err)
+	}
+	applied, err := applyPragmas(writeDB, opts)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("sqlite: applied pragmas on %s: %s", dbPath, strings.Join(applied, ", "))
+
+	sqliteDB := &SQLiteDB{
+		db:   writeDB,
+		path: dbPath,
+	}
+
+	if strings.EqualFold(opts.JournalMode, "WAL") {
+		readDB, err := sql.Open("sqlite", dbPath+"?mode=ro")
+		if err != nil {
+			_ = writeDB.Close()
+			return nil, fmt.Errorf("failed to open read-only pool: %w", err)
+		}
+		readDB.SetMaxOpenConns(opts.MaxReadConns)
+		readDB.SetMaxIdleConns(opts.MaxReadConns)
+		readDB.SetConnMaxLifetime(time.Hour)
+		if err := readDB.Ping(); err != nil {
+			_ = writeDB.Close()
+			return nil, fmt.Errorf("read-only pool ping failed: %w", err)
+		}
+		if _, err := readDB.Exec(fmt.Sprintf("PRAGMA busy_timeout = %d;", opts.BusyTimeout)); err != nil {
+			_ = writeDB.Close()
+			_ = readDB.Close()
+			return nil, fmt.Errorf("failed to set read-only pool busy_timeout: %w", err)
+		}
+		sqliteDB.readDB = readDB
+	}
+
+	return sqliteDB, nil
+}
+
+// applyPragmas run the configured PRAGMA statements against db, returning the applied
+// statements (minus the "PRAGMA " prefix) for logging
+func applyPragmas(db *sql.DB, opts SQLiteOptions) ([]string, error) {
+	statements := []string{
+		fmt.Sprintf("journal_mode = %s", opts.JournalMode),
+		fmt.Sprintf("synchronous = %s", opts.Synchronous),
+		fmt.Sprintf("busy_timeout = %d", opts.BusyTimeout),
+	}
+	if opts.CacheSize != 0 {
+		statements = append(statements, fmt.Sprintf("cache_size = %d", opts.CacheSize))
+	}
+	if opts.ForeignKeys != nil && *opts.ForeignKeys {
+		statements = append(statements, "foreign_keys = ON")
+	}
+	if opts.MmapSize != 0 {
+		statements = append(statements, fmt.Sprintf("mmap_size = %d", opts.MmapSize))
+	}
+	for _, stmt := range statements {
+		if _, err := db.Exec("PRAGMA " + stmt + ";"); err != nil {
+			return nil, fmt.Errorf("failed to apply pragma %q: %w", stmt, err)
+		}
+	}
+	return statements, nil
+}
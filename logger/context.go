@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ctxKey local type so our context keys never collide with other packages'
+type ctxKey int
+
+const (
+	loggerCtxKey ctxKey = iota
+	requestIDCtxKey
+)
+
+// WithContext attach logger l to ctx, retrievable later via FromContext
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, l)
+}
+
+// ContextWithRequestID attach a request id to ctx, surfaced as the request_id field by FromContext
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey, requestID)
+}
+
+// FromContext return the logger attached to ctx (via WithContext), falling back to the
+// global logger, enriched with trace_id/span_id from an active OTel span and request_id
+// when present in ctx
+func FromContext(ctx context.Context) *zap.Logger {
+	l := baseLogger()
+	if stored, ok := ctx.Value(loggerCtxKey).(*zap.Logger); ok && stored != nil {
+		l = stored
+	}
+	if fields := contextFields(ctx); len(fields) > 0 {
+		l = l.With(fields...)
+	}
+	return l
+}
+
+// contextFields derive trace_id/span_id/request_id zap fields from ctx
+func contextFields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", span.TraceID().String()),
+			zap.String("span_id", span.SpanID().String()),
+		)
+	}
+	if requestID, ok := ctx.Value(requestIDCtxKey).(string); ok && requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	return fields
+}
+
+// Base expose the underlying *zap.Logger, for hot paths that should avoid SugaredLogger reflection
+func Base() *zap.Logger {
+	return baseLogger()
+}
+
+// Named return a subsystem-scoped *zap.Logger
+func Named(name string) *zap.Logger {
+	return baseLogger().Named(name)
+}
+
+// baseLogger lazily initialize the global logger if needed and return the underlying *zap.Logger
+func baseLogger() *zap.Logger {
+	if globalBase == nil {
+		if err := Init(Config{
+			Level:    InfoLevel,
+			Console:  true,
+			Filename: "./logs/app.log",
+			MaxSize:  100,
+			MaxAge:   7,
+		}); err != nil {
+			return zap.NewNop()
+		}
+	}
+	return globalBase
+}
+
+// DebugCtx log at debug level with fields, enriched with ctx's trace/request correlation
+func DebugCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Debug(msg, fields...)
+}
+
+// InfoCtx log at info level with fields, enriched with ctx's trace/request correlation
+func InfoCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Info(msg, fields...)
+}
+
+// WarnCtx log at warn level with fields, enriched with ctx's trace/request correlation
+func WarnCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Warn(msg, fields...)
+}
+
+// ErrorCtx log at error level with fields, enriched with ctx's trace/request correlation
+func ErrorCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Error(msg, fields...)
+}
+
+// PanicCtx log at panic level with fields, enriched with ctx's trace/request correlation
+func PanicCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Panic(msg, fields...)
+}
+
+// FatalCtx log at fatal level with fields, enriched with ctx's trace/request correlation
+func FatalCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Fatal(msg, fields...)
+}
@@ -1,12 +1,15 @@
 package logger
 
 import (
-	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
+	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // LogLevel log level user define
@@ -34,6 +37,8 @@ type Config struct {
 
 var (
 	globalLogger *zap.SugaredLogger
+	globalBase   *zap.Logger
+	atomicLevel  zap.AtomicLevel
 )
 
 // Init global logger
@@ -43,6 +48,8 @@ func Init(cfg Config) error {
 	if logLevel == zapcore.InvalidLevel {
 		logLevel = zapcore.InfoLevel
 	}
+	// atomic level shared by every core, so SetLevel reconfigures them all at once
+	atomicLevel = zap.NewAtomicLevelAt(logLevel)
 	// create zap core
 	var cores []zapcore.Core
 	// output file core
@@ -63,7 +70,7 @@ func Init(cfg Config) error {
 		fileCore := zapcore.NewCore(
 			getJSONEncoder(),
 			fileWriter,
-			logLevel,
+			atomicLevel,
 		)
 		cores = append(cores, fileCore)
 	}
@@ -72,7 +79,7 @@ func Init(cfg Config) error {
 		consoleCore := zapcore.NewCore(
 			getConsoleEncoder(),
 			zapcore.Lock(os.Stdout),
-			logLevel,
+			atomicLevel,
 		)
 		cores = append(cores, consoleCore)
 	}
@@ -80,8 +87,8 @@ func Init(cfg Config) error {
 	core := zapcore.NewTee(cores...)
 	// create logger with debug information
 	logger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
-	defer logger.Sync()
 	// create SugaredLogger
+	globalBase = logger
 	globalLogger = logger.Sugar()
 	return nil
 }
@@ -147,6 +154,55 @@ func mapLogLevel(level LogLevel) zapcore.Level {
 	}
 }
 
+// unmapLogLevel map zapcore level back to LogLevel
+func unmapLogLevel(level zapcore.Level) LogLevel {
+	switch level {
+	case zapcore.DebugLevel:
+		return DebugLevel
+	case zapcore.InfoLevel:
+		return InfoLevel
+	case zapcore.WarnLevel:
+		return WarnLevel
+	case zapcore.ErrorLevel:
+		return ErrorLevel
+	case zapcore.PanicLevel:
+		return PanicLevel
+	case zapcore.FatalLevel:
+		return FatalLevel
+	default:
+		return InfoLevel
+	}
+}
+
+// SetLevel change the running log level of every core built by Init, without a restart
+func SetLevel(level LogLevel) error {
+	zapLevel := mapLogLevel(level)
+	if zapLevel == zapcore.InvalidLevel {
+		return fmt.Errorf("invalid log level: %s", level)
+	}
+	atomicLevel.SetLevel(zapLevel)
+	return nil
+}
+
+// GetLevel return the current running log level
+func GetLevel() LogLevel {
+	return unmapLogLevel(atomicLevel.Level())
+}
+
+// LevelHandler return an http.Handler compatible with zap's AtomicLevel HTTP semantics:
+// GET returns the current level as JSON, PUT with {"level":"debug"} changes it at runtime
+func LevelHandler() http.Handler {
+	return atomicLevel
+}
+
+// Sync flush any buffered log entries, should be deferred by callers after Init
+func Sync() error {
+	if globalBase != nil {
+		return globalBase.Sync()
+	}
+	return nil
+}
+
 // Logger get global logger
 func Logger() *zap.SugaredLogger {
 	if globalLogger == nil {